@@ -0,0 +1,207 @@
+package main
+
+import (
+	"encoding/json"
+	"sync"
+	"syscall/js"
+
+	"github.com/ouroboros-chimera/neural-cluster/journal"
+	"github.com/ouroboros-chimera/neural-cluster/policy"
+)
+
+var (
+	journalMutex sync.Mutex
+	journals     = make(map[string]*journal.Log)
+)
+
+// journalFor returns the journal for a cluster, creating one backed by
+// IndexedDB on first use.
+func journalFor(id string) *journal.Log {
+	journalMutex.Lock()
+	defer journalMutex.Unlock()
+
+	if log, ok := journals[id]; ok {
+		return log
+	}
+	log := journal.New(idbPersister{clusterID: id})
+	journals[id] = log
+	return log
+}
+
+// journalDecision appends a produced decision to its cluster's journal.
+func journalDecision(id string, decision Decision) {
+	journalFor(id).Append(journal.KindDecision, decision)
+}
+
+// journalStateChange appends an updateClusterState mutation to its cluster's journal.
+func journalStateChange(id string, state map[string]float64) {
+	journalFor(id).Append(journal.KindStateChange, state)
+}
+
+// clusterSnapshot is the JSON shape returned by goSnapshotCluster and accepted by goRestoreCluster.
+type clusterSnapshot struct {
+	State   map[string]float64 `json:"state"`
+	LastSeq uint64             `json:"lastSeq"`
+}
+
+// snapshotCluster returns a cluster's current state and journal sequence
+// number, suitable for warm-booting the cluster later via goRestoreCluster.
+func snapshotCluster(this js.Value, args []js.Value) interface{} {
+	if len(args) < 1 {
+		return js.Null()
+	}
+	id := args[0].String()
+
+	clusterMutex.RLock()
+	cluster, exists := clusters[id]
+	clusterMutex.RUnlock()
+	if !exists {
+		return js.Null()
+	}
+
+	cluster.mutex.RLock()
+	state := make(map[string]float64, len(cluster.State))
+	for k, v := range cluster.State {
+		state[k] = v
+	}
+	cluster.mutex.RUnlock()
+
+	snapshot := clusterSnapshot{State: state, LastSeq: journalFor(id).LastSeq()}
+	snapshotJSON, err := json.Marshal(snapshot)
+	if err != nil {
+		return js.Null()
+	}
+	return js.ValueOf(string(snapshotJSON))
+}
+
+// replayCluster returns every decision journaled for a cluster after fromSeq, in order.
+func replayCluster(this js.Value, args []js.Value) interface{} {
+	if len(args) < 2 {
+		return js.Null()
+	}
+	id := args[0].String()
+	fromSeq := uint64(args[1].Int())
+
+	entries := journalFor(id).Since(fromSeq)
+	decisions := make([]json.RawMessage, 0, len(entries))
+	for _, e := range entries {
+		if e.Kind == journal.KindDecision {
+			decisions = append(decisions, e.Payload)
+		}
+	}
+
+	decisionsJSON, err := json.Marshal(decisions)
+	if err != nil {
+		return js.Null()
+	}
+	return js.ValueOf(string(decisionsJSON))
+}
+
+// restoreCluster warm-boots a cluster from a snapshot taken by goSnapshotCluster,
+// compacting the journal up to the snapshot's sequence number.
+func restoreCluster(this js.Value, args []js.Value) interface{} {
+	if len(args) < 2 {
+		return js.ValueOf("error: cluster ID and snapshot JSON required")
+	}
+	id := args[0].String()
+
+	var snapshot clusterSnapshot
+	if err := json.Unmarshal([]byte(args[1].String()), &snapshot); err != nil {
+		return js.ValueOf("error: invalid snapshot JSON")
+	}
+
+	defaultPolicy, _ := policy.New("threshold", nil)
+	cluster := &NeuralCluster{
+		ID:        id,
+		State:     snapshot.State,
+		Active:    true,
+		Policy:    defaultPolicy,
+		decisions: make(chan Decision, 100),
+		stopChan:  make(chan struct{}),
+		strategy:  strategyDropNewest,
+	}
+	if cluster.State == nil {
+		cluster.State = make(map[string]float64)
+	}
+
+	clusterMutex.Lock()
+	clusters[id] = cluster
+	clusterMutex.Unlock()
+
+	log := journalFor(id)
+	log.Restore(snapshot.LastSeq)
+	log.Compact(snapshot.LastSeq)
+
+	superviseCluster(id)
+	go cluster.processDecisions()
+
+	return js.ValueOf("ok")
+}
+
+// idbPersister durably mirrors journal entries into IndexedDB so a cluster's
+// history survives a page reload. Writes are fire-and-forget: the in-memory
+// journal.Log remains authoritative for the running session.
+type idbPersister struct {
+	clusterID string
+}
+
+// idbDatabaseName is the shared IndexedDB database backing every cluster's
+// journal. All clusters share a single object store, keyed by
+// (clusterId, seq), so opening the database never needs a version bump when
+// a new cluster ID shows up.
+const idbDatabaseName = "ourobos-journal"
+const idbStoreName = "entries"
+
+func (p idbPersister) withStore(mode string, fn func(store js.Value)) {
+	indexedDB := js.Global().Get("indexedDB")
+	if indexedDB.IsUndefined() {
+		return
+	}
+
+	request := indexedDB.Call("open", idbDatabaseName, 1)
+	request.Set("onupgradeneeded", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		db := args[0].Get("target").Get("result")
+		if !db.Get("objectStoreNames").Call("contains", idbStoreName).Bool() {
+			db.Call("createObjectStore", idbStoreName, map[string]interface{}{
+				"keyPath": []interface{}{"clusterId", "seq"},
+			})
+		}
+		return nil
+	}))
+	request.Set("onsuccess", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		db := args[0].Get("target").Get("result")
+		tx := db.Call("transaction", []interface{}{idbStoreName}, mode)
+		store := tx.Call("objectStore", idbStoreName)
+		fn(store)
+		return nil
+	}))
+}
+
+// Persist implements journal.Persister.
+func (p idbPersister) Persist(entry journal.Entry) {
+	entryJSON, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	var record map[string]interface{}
+	if err := json.Unmarshal(entryJSON, &record); err != nil {
+		return
+	}
+	record["clusterId"] = p.clusterID
+
+	p.withStore("readwrite", func(store js.Value) {
+		store.Call("put", js.ValueOf(record))
+	})
+}
+
+// Compact implements journal.Persister, dropping every persisted entry for
+// this cluster with Seq <= uptoSeq. The compound key range is bounded to
+// this cluster's ID so other clusters' entries in the shared store are untouched.
+func (p idbPersister) Compact(uptoSeq uint64) {
+	p.withStore("readwrite", func(store js.Value) {
+		lower := js.ValueOf([]interface{}{p.clusterID, 0})
+		upper := js.ValueOf([]interface{}{p.clusterID, uptoSeq})
+		keyRange := js.Global().Get("IDBKeyRange").Call("bound", lower, upper)
+		store.Call("delete", keyRange)
+	})
+}
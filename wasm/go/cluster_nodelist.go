@@ -0,0 +1,142 @@
+package main
+
+import (
+	"encoding/json"
+	"syscall/js"
+	"time"
+
+	"github.com/ouroboros-chimera/neural-cluster/nodelist"
+)
+
+// matchingClusterIDs returns the IDs of currently registered clusters that match expr.
+func matchingClusterIDs(expr *nodelist.Expr) []string {
+	clusterMutex.RLock()
+	defer clusterMutex.RUnlock()
+
+	var ids []string
+	for id := range clusters {
+		if expr.Contains(id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// broadcastStateUpdate applies a state update to every cluster matched by a nodelist expression.
+func broadcastStateUpdate(this js.Value, args []js.Value) interface{} {
+	if len(args) < 2 {
+		return js.ValueOf("error: nodelist expression and state JSON required")
+	}
+
+	expr, err := nodelist.Parse(args[0].String())
+	if err != nil {
+		return js.ValueOf("error: " + err.Error())
+	}
+
+	var state map[string]float64
+	if err := json.Unmarshal([]byte(args[1].String()), &state); err != nil {
+		return js.ValueOf("error: invalid JSON")
+	}
+
+	for _, id := range matchingClusterIDs(expr) {
+		clusterMutex.RLock()
+		cluster, exists := clusters[id]
+		clusterMutex.RUnlock()
+		if !exists {
+			continue
+		}
+
+		applyStateToCluster(id, cluster, state)
+	}
+
+	return js.ValueOf("ok")
+}
+
+// collectDecisions drains the next available decision from every cluster
+// matched by expr, waiting up to timeoutMs in total. Clusters are raced
+// concurrently rather than polled one at a time, so a single stalled or
+// dead cluster can't starve decisions already sitting in the others.
+func collectDecisions(this js.Value, args []js.Value) interface{} {
+	if len(args) < 2 {
+		return js.Null()
+	}
+
+	expr, err := nodelist.Parse(args[0].String())
+	if err != nil {
+		return js.Null()
+	}
+	timeoutMs := args[1].Int()
+
+	var clustersToWatch []*NeuralCluster
+	for _, id := range matchingClusterIDs(expr) {
+		clusterMutex.RLock()
+		cluster, exists := clusters[id]
+		clusterMutex.RUnlock()
+		if exists {
+			clustersToWatch = append(clustersToWatch, cluster)
+		}
+	}
+
+	results := make(chan Decision, len(clustersToWatch))
+	done := make(chan struct{})
+
+	for _, cluster := range clustersToWatch {
+		go func(cluster *NeuralCluster) {
+			select {
+			case decision := <-cluster.decisions:
+				results <- decision
+			case <-done:
+			}
+		}(cluster)
+	}
+
+	deadline := time.After(time.Duration(timeoutMs) * time.Millisecond)
+	decisions := make([]Decision, 0, len(clustersToWatch))
+
+collectLoop:
+	for range clustersToWatch {
+		select {
+		case decision := <-results:
+			decisions = append(decisions, decision)
+		case <-deadline:
+			break collectLoop
+		}
+	}
+
+	close(done)
+	return marshalDecisions(decisions)
+}
+
+func marshalDecisions(decisions []Decision) interface{} {
+	decisionsJSON, err := json.Marshal(decisions)
+	if err != nil {
+		return js.Null()
+	}
+	return js.ValueOf(string(decisionsJSON))
+}
+
+// stopClusters stops every cluster matched by a nodelist expression.
+func stopClusters(this js.Value, args []js.Value) interface{} {
+	if len(args) < 1 {
+		return js.ValueOf("error: nodelist expression required")
+	}
+
+	expr, err := nodelist.Parse(args[0].String())
+	if err != nil {
+		return js.ValueOf("error: " + err.Error())
+	}
+
+	for _, id := range matchingClusterIDs(expr) {
+		clusterMutex.Lock()
+		cluster, exists := clusters[id]
+		if exists {
+			cluster.Active = false
+			close(cluster.stopChan)
+			delete(clusters, id)
+			unsuperviseCluster(id)
+		}
+		clusterMutex.Unlock()
+	}
+
+	return js.ValueOf("ok")
+}
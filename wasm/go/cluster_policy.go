@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"syscall/js"
+
+	"github.com/ouroboros-chimera/neural-cluster/policy"
+)
+
+// createClusterWithPolicy creates a neural cluster whose decisions are made by a named, parameterized policy.
+func createClusterWithPolicy(this js.Value, args []js.Value) interface{} {
+	if len(args) < 2 {
+		return js.ValueOf("error: cluster ID and policy name required")
+	}
+
+	id := args[0].String()
+	policyName := args[1].String()
+
+	var params json.RawMessage
+	if len(args) >= 3 && args[2].String() != "" {
+		params = json.RawMessage(args[2].String())
+	}
+
+	p, err := policy.New(policyName, params)
+	if err != nil {
+		return js.ValueOf("error: " + err.Error())
+	}
+
+	cluster := &NeuralCluster{
+		ID:        id,
+		State:     make(map[string]float64),
+		Active:    true,
+		Policy:    p,
+		decisions: make(chan Decision, 100),
+		stopChan:  make(chan struct{}),
+		strategy:  strategyDropNewest,
+	}
+
+	cluster.State["population"] = 50.0
+	cluster.State["energy"] = 50.0
+	cluster.State["mutation_rate"] = 0.05
+
+	clusterMutex.Lock()
+	clusters[id] = cluster
+	clusterMutex.Unlock()
+
+	superviseCluster(id)
+	go cluster.processDecisions()
+
+	return js.ValueOf(id)
+}
+
+// setClusterPolicy swaps a running cluster's decision policy for a different named one, using its default parameters.
+func setClusterPolicy(this js.Value, args []js.Value) interface{} {
+	if len(args) < 2 {
+		return js.ValueOf("error: cluster ID and policy name required")
+	}
+
+	id := args[0].String()
+	policyName := args[1].String()
+
+	clusterMutex.RLock()
+	cluster, exists := clusters[id]
+	clusterMutex.RUnlock()
+	if !exists {
+		return js.ValueOf("error: cluster not found")
+	}
+
+	p, err := policy.New(policyName, nil)
+	if err != nil {
+		return js.ValueOf("error: " + err.Error())
+	}
+
+	cluster.mutex.Lock()
+	cluster.Policy = p
+	cluster.mutex.Unlock()
+
+	return js.ValueOf("ok")
+}
+
+// jsPolicy adapts a JavaScript callback into a policy.DecisionPolicy, so a
+// policy can be authored in JS and driven from the same registry as the
+// built-in Go policies.
+type jsPolicy struct {
+	callback js.Value
+}
+
+// Decide implements policy.DecisionPolicy by invoking the JS callback with
+// the state JSON and parsing its {action, confidence} return value.
+func (p jsPolicy) Decide(state map[string]float64) policy.Outcome {
+	stateJSON, err := json.Marshal(state)
+	if err != nil {
+		return policy.Outcome{Action: "maintain", Confidence: 0}
+	}
+
+	result := p.callback.Invoke(string(stateJSON))
+
+	var outcome struct {
+		Action     string  `json:"action"`
+		Confidence float64 `json:"confidence"`
+	}
+	if err := json.Unmarshal([]byte(result.String()), &outcome); err != nil {
+		return policy.Outcome{Action: "maintain", Confidence: 0}
+	}
+
+	return policy.Outcome{Action: outcome.Action, Confidence: outcome.Confidence}
+}
+
+// registerJSPolicy registers a JavaScript-implemented policy under a name so
+// it can be selected via goCreateClusterWithPolicy/goSetClusterPolicy just
+// like a built-in.
+func registerJSPolicy(this js.Value, args []js.Value) interface{} {
+	if len(args) < 2 {
+		return js.ValueOf("error: policy name and callback required")
+	}
+
+	name := args[0].String()
+	callback := args[1]
+
+	policy.RegisterPolicy(name, func(json.RawMessage) (policy.DecisionPolicy, error) {
+		return jsPolicy{callback: callback}, nil
+	})
+
+	return js.ValueOf("ok")
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"encoding/json"
+	"syscall/js"
+)
+
+// bufferStrategy controls what a cluster does with a decision when its
+// delivery buffer is full, replacing the old fixed drop-newest behavior.
+type bufferStrategy string
+
+const (
+	strategyBlock      bufferStrategy = "block"
+	strategyDropOldest bufferStrategy = "drop-oldest"
+	strategyDropNewest bufferStrategy = "drop-newest"
+	strategyCoalesce   bufferStrategy = "coalesce"
+)
+
+// clusterMetrics counts what happened to decisions a cluster produced, so
+// callers can tune ticker rate against how fast they actually consume.
+type clusterMetrics struct {
+	Produced  uint64 `json:"produced"`
+	Delivered uint64 `json:"delivered"`
+	Dropped   uint64 `json:"dropped"`
+	Coalesced uint64 `json:"coalesced"`
+}
+
+// deliver routes a freshly produced decision to the legacy poll channel and
+// to any subscribers, applying the cluster's buffering strategy.
+func (nc *NeuralCluster) deliver(decision Decision) {
+	nc.metricsMu.Lock()
+	nc.metrics.Produced++
+	nc.metricsMu.Unlock()
+
+	if nc.strategy == strategyCoalesce {
+		nc.pendingMu.Lock()
+		if nc.pending != nil && nc.pending.Action == decision.Action {
+			nc.pending.Coalesced++
+			nc.pendingMu.Unlock()
+			nc.metricsMu.Lock()
+			nc.metrics.Coalesced++
+			nc.metricsMu.Unlock()
+			return
+		}
+		flushed := nc.pending
+		pending := decision
+		nc.pending = &pending
+		nc.pendingMu.Unlock()
+
+		if flushed != nil {
+			nc.enqueue(*flushed)
+		}
+		return
+	}
+
+	nc.enqueue(decision)
+}
+
+// enqueue pushes decision onto the legacy poll channel per the cluster's
+// buffering strategy and fans it out to subscribers.
+func (nc *NeuralCluster) enqueue(decision Decision) {
+	var delivered bool
+
+	switch nc.strategy {
+	case strategyBlock:
+		nc.decisions <- decision
+		delivered = true
+	case strategyDropOldest:
+		select {
+		case nc.decisions <- decision:
+			delivered = true
+		default:
+			select {
+			case <-nc.decisions:
+				nc.metricsMu.Lock()
+				nc.metrics.Dropped++
+				nc.metricsMu.Unlock()
+			default:
+			}
+			select {
+			case nc.decisions <- decision:
+				delivered = true
+			default:
+			}
+		}
+	default: // drop-newest, and the coalesce flush path
+		select {
+		case nc.decisions <- decision:
+			delivered = true
+		default:
+			nc.metricsMu.Lock()
+			nc.metrics.Dropped++
+			nc.metricsMu.Unlock()
+		}
+	}
+
+	if delivered {
+		nc.metricsMu.Lock()
+		nc.metrics.Delivered++
+		nc.metricsMu.Unlock()
+	}
+
+	nc.notifySubscribers(decision)
+}
+
+// notifySubscribers invokes every subscribed JS callback with the decision's
+// JSON encoding. Calls are queued as microtasks rather than invoked inline,
+// so a callback that triggers another Go call can't re-enter this goroutine
+// mid-delivery.
+func (nc *NeuralCluster) notifySubscribers(decision Decision) {
+	decisionJSON, err := json.Marshal(decision)
+	if err != nil {
+		return
+	}
+
+	nc.subMutex.Lock()
+	callbacks := append([]js.Value(nil), nc.subscribers...)
+	nc.subMutex.Unlock()
+
+	for _, callback := range callbacks {
+		callback := callback
+		js.Global().Call("queueMicrotask", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			callback.Invoke(string(decisionJSON))
+			return nil
+		}))
+	}
+}
+
+// subscribeDecisions registers a JS callback invoked with each decision a
+// cluster produces, and optionally sets its buffering strategy.
+func subscribeDecisions(this js.Value, args []js.Value) interface{} {
+	if len(args) < 2 {
+		return js.ValueOf("error: cluster ID and callback required")
+	}
+
+	id := args[0].String()
+	callback := args[1]
+
+	clusterMutex.RLock()
+	cluster, exists := clusters[id]
+	clusterMutex.RUnlock()
+	if !exists {
+		return js.ValueOf("error: cluster not found")
+	}
+
+	if len(args) >= 3 {
+		switch bufferStrategy(args[2].String()) {
+		case strategyBlock, strategyDropOldest, strategyDropNewest, strategyCoalesce:
+			cluster.strategy = bufferStrategy(args[2].String())
+		default:
+			return js.ValueOf("error: unknown buffering strategy")
+		}
+	}
+
+	cluster.subMutex.Lock()
+	cluster.subscribers = append(cluster.subscribers, callback)
+	cluster.subMutex.Unlock()
+
+	return js.ValueOf("ok")
+}
+
+// getClusterMetrics returns a cluster's produced/delivered/dropped/coalesced counters as JSON.
+func getClusterMetrics(this js.Value, args []js.Value) interface{} {
+	if len(args) < 1 {
+		return js.Null()
+	}
+	id := args[0].String()
+
+	clusterMutex.RLock()
+	cluster, exists := clusters[id]
+	clusterMutex.RUnlock()
+	if !exists {
+		return js.Null()
+	}
+
+	cluster.metricsMu.Lock()
+	metrics := cluster.metrics
+	cluster.metricsMu.Unlock()
+
+	metricsJSON, err := json.Marshal(metrics)
+	if err != nil {
+		return js.Null()
+	}
+	return js.ValueOf(string(metricsJSON))
+}
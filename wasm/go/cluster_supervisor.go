@@ -0,0 +1,293 @@
+package main
+
+import (
+	"encoding/json"
+	"sync"
+	"syscall/js"
+	"time"
+)
+
+// restartPolicy bounds how a stalled cluster is auto-restarted: up to
+// MaxRestarts attempts within WindowMs, with the nth attempt in the window
+// (counting from 0) delayed by BackoffMs * 2^n, capped at WindowMs.
+type restartPolicy struct {
+	MaxRestarts int `json:"maxRestarts"`
+	BackoffMs   int `json:"backoffMs"`
+	WindowMs    int `json:"window"`
+}
+
+// clusterConfig is the JSON shape accepted by goConfigureCluster.
+type clusterConfig struct {
+	TickerMs      int           `json:"tickerMs"`
+	HeartbeatMs   int           `json:"heartbeatMs"`
+	RestartPolicy restartPolicy `json:"restartPolicy"`
+}
+
+func defaultClusterConfig() clusterConfig {
+	return clusterConfig{
+		TickerMs:    100,
+		HeartbeatMs: 1000,
+		RestartPolicy: restartPolicy{
+			MaxRestarts: 3,
+			BackoffMs:   200,
+			WindowMs:    10000,
+		},
+	}
+}
+
+// supervisor tracks liveness for one cluster: its config, the last tick it
+// reported, and the restart attempts made within the current window.
+type supervisor struct {
+	mutex    sync.Mutex
+	config   clusterConfig
+	lastTick time.Time
+	restarts []time.Time
+	dead     bool
+	onStall  []js.Value
+}
+
+var (
+	supervisorMutex sync.Mutex
+	supervisors     = make(map[string]*supervisor)
+	watchdogOnce    sync.Once
+)
+
+// superviseCluster registers a cluster with the supervisor, using default
+// config, and starts the shared watchdog goroutine on first use.
+func superviseCluster(id string) *supervisor {
+	watchdogOnce.Do(func() { go runWatchdog() })
+
+	supervisorMutex.Lock()
+	defer supervisorMutex.Unlock()
+
+	sup, ok := supervisors[id]
+	if !ok {
+		sup = &supervisor{config: defaultClusterConfig(), lastTick: time.Now()}
+		supervisors[id] = sup
+	}
+	return sup
+}
+
+// unsuperviseCluster removes a cluster's supervisor entry. Called on a
+// deliberate stop so the watchdog doesn't mistake a clean shutdown for a
+// stall and burn phantom restart attempts against a cluster that no longer
+// exists, and so long-running apps don't leak a supervisor per cluster they ever created.
+func unsuperviseCluster(id string) {
+	supervisorMutex.Lock()
+	delete(supervisors, id)
+	supervisorMutex.Unlock()
+}
+
+// recordTick marks a cluster as having ticked just now, resetting its stall clock.
+func recordTick(id string) {
+	supervisorMutex.Lock()
+	sup, ok := supervisors[id]
+	supervisorMutex.Unlock()
+	if !ok {
+		return
+	}
+
+	sup.mutex.Lock()
+	sup.lastTick = time.Now()
+	sup.mutex.Unlock()
+}
+
+// runWatchdog periodically checks every supervised cluster for a stalled heartbeat.
+func runWatchdog() {
+	ticker := time.NewTicker(200 * time.Millisecond)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		supervisorMutex.Lock()
+		ids := make([]string, 0, len(supervisors))
+		for id := range supervisors {
+			ids = append(ids, id)
+		}
+		supervisorMutex.Unlock()
+
+		for _, id := range ids {
+			checkStall(id)
+		}
+	}
+}
+
+// checkStall fires OnStall and attempts a restart if a cluster has gone
+// silent for longer than its configured heartbeat period.
+func checkStall(id string) {
+	supervisorMutex.Lock()
+	sup, ok := supervisors[id]
+	supervisorMutex.Unlock()
+	if !ok {
+		return
+	}
+
+	sup.mutex.Lock()
+	if sup.dead {
+		sup.mutex.Unlock()
+		return
+	}
+	stalled := time.Since(sup.lastTick) > time.Duration(sup.config.HeartbeatMs)*time.Millisecond
+	callbacks := append([]js.Value(nil), sup.onStall...)
+	sup.mutex.Unlock()
+
+	if !stalled {
+		return
+	}
+
+	for _, callback := range callbacks {
+		callback := callback
+		js.Global().Call("queueMicrotask", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			callback.Invoke(id)
+			return nil
+		}))
+	}
+
+	attemptRestart(id, sup)
+}
+
+// attemptRestart restarts a stalled cluster's decision loop with exponential
+// backoff, marking it dead once it has exhausted its restart budget within
+// the configured window.
+func attemptRestart(id string, sup *supervisor) {
+	now := time.Now()
+
+	sup.mutex.Lock()
+	window := time.Duration(sup.config.RestartPolicy.WindowMs) * time.Millisecond
+	kept := sup.restarts[:0]
+	for _, t := range sup.restarts {
+		if now.Sub(t) <= window {
+			kept = append(kept, t)
+		}
+	}
+	sup.restarts = kept
+
+	if len(sup.restarts) >= sup.config.RestartPolicy.MaxRestarts {
+		sup.dead = true
+		sup.mutex.Unlock()
+		return
+	}
+
+	attempt := len(sup.restarts)
+	sup.restarts = append(sup.restarts, now)
+	backoff := time.Duration(sup.config.RestartPolicy.BackoffMs) * time.Millisecond * time.Duration(1<<uint(attempt))
+	if backoff > window {
+		backoff = window
+	}
+	sup.mutex.Unlock()
+
+	go func() {
+		time.Sleep(backoff)
+
+		clusterMutex.RLock()
+		cluster, exists := clusters[id]
+		clusterMutex.RUnlock()
+		if !exists {
+			return
+		}
+
+		cluster.mutex.Lock()
+		oldStop := cluster.stopChan
+		cluster.Active = true
+		cluster.stopChan = make(chan struct{})
+		cluster.mutex.Unlock()
+
+		// Signal the stalled goroutine to exit, in case it is still
+		// selecting on its ticker/stopChan; a goroutine truly wedged on a
+		// blocking send (e.g. the "block" buffering strategy) is abandoned.
+		closeStopChan(oldStop)
+
+		go cluster.processDecisions()
+
+		sup.mutex.Lock()
+		sup.lastTick = time.Now()
+		sup.mutex.Unlock()
+	}()
+}
+
+// closeStopChan closes a stop channel, tolerating one that is already closed.
+func closeStopChan(ch chan struct{}) {
+	defer func() { recover() }()
+	close(ch)
+}
+
+// configureCluster sets a cluster's ticker rate, heartbeat timeout, and restart policy.
+func configureCluster(this js.Value, args []js.Value) interface{} {
+	if len(args) < 2 {
+		return js.ValueOf("error: cluster ID and config JSON required")
+	}
+	id := args[0].String()
+
+	clusterMutex.RLock()
+	_, exists := clusters[id]
+	clusterMutex.RUnlock()
+	if !exists {
+		return js.ValueOf("error: cluster not found")
+	}
+
+	config := defaultClusterConfig()
+	if err := json.Unmarshal([]byte(args[1].String()), &config); err != nil {
+		return js.ValueOf("error: invalid config JSON")
+	}
+
+	sup := superviseCluster(id)
+	sup.mutex.Lock()
+	sup.config = config
+	sup.lastTick = time.Now()
+	sup.mutex.Unlock()
+
+	return js.ValueOf("ok")
+}
+
+// onClusterStall registers a JS callback invoked with a cluster's ID whenever its watchdog detects a stall.
+func onClusterStall(this js.Value, args []js.Value) interface{} {
+	if len(args) < 2 {
+		return js.ValueOf("error: cluster ID and callback required")
+	}
+	id := args[0].String()
+	callback := args[1]
+
+	sup := superviseCluster(id)
+	sup.mutex.Lock()
+	sup.onStall = append(sup.onStall, callback)
+	sup.mutex.Unlock()
+
+	return js.ValueOf("ok")
+}
+
+// clusterHealth is the JSON shape returned by goGetClusterHealth.
+type clusterHealth struct {
+	Alive        bool  `json:"alive"`
+	Dead         bool  `json:"dead"`
+	LastTickUnix int64 `json:"lastTickUnix"`
+	Restarts     int   `json:"restarts"`
+}
+
+// getClusterHealth reports a cluster's liveness as tracked by its supervisor.
+func getClusterHealth(this js.Value, args []js.Value) interface{} {
+	if len(args) < 1 {
+		return js.Null()
+	}
+	id := args[0].String()
+
+	supervisorMutex.Lock()
+	sup, ok := supervisors[id]
+	supervisorMutex.Unlock()
+	if !ok {
+		return js.Null()
+	}
+
+	sup.mutex.Lock()
+	health := clusterHealth{
+		Alive:        !sup.dead,
+		Dead:         sup.dead,
+		LastTickUnix: sup.lastTick.UnixMilli(),
+		Restarts:     len(sup.restarts),
+	}
+	sup.mutex.Unlock()
+
+	healthJSON, err := json.Marshal(health)
+	if err != nil {
+		return js.Null()
+	}
+	return js.ValueOf(string(healthJSON))
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"syscall/js"
+	"time"
+
+	crdt "github.com/ouroboros-chimera/neural-cluster/sync"
+)
+
+// clusterSync holds the CRDT replication state and gossip connections for a
+// single cluster. It is kept separate from NeuralCluster so that sync stays
+// opt-in: clusters that never call goEnableClusterSync pay no cost for it.
+type clusterSync struct {
+	mutex   sync.Mutex
+	state   *crdt.State
+	peers   []js.Value
+	onMsg   js.Func
+}
+
+var (
+	syncClusters = make(map[string]*clusterSync)
+	syncMutex    sync.RWMutex
+)
+
+// enableClusterSync wraps a cluster's state in a CRDT store and opens a
+// WebSocket to every peer address for delta gossip.
+func enableClusterSync(this js.Value, args []js.Value) interface{} {
+	if len(args) < 2 {
+		return js.ValueOf("error: cluster ID and peer addresses required")
+	}
+
+	id := args[0].String()
+	var peerAddrs []string
+	if err := json.Unmarshal([]byte(args[1].String()), &peerAddrs); err != nil {
+		return js.ValueOf("error: invalid peer addresses JSON")
+	}
+
+	clusterMutex.RLock()
+	cluster, exists := clusters[id]
+	clusterMutex.RUnlock()
+	if !exists {
+		return js.ValueOf("error: cluster not found")
+	}
+
+	peerID := fmt.Sprintf("%s-%d", id, time.Now().UnixNano())
+	cs := &clusterSync{state: crdt.NewState(peerID)}
+
+	cluster.mutex.RLock()
+	for key, value := range cluster.State {
+		cs.state.SetLocal(key, value)
+	}
+	cluster.mutex.RUnlock()
+
+	cs.onMsg = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		var delta crdt.Delta
+		if err := json.Unmarshal([]byte(args[0].Get("data").String()), &delta); err != nil {
+			return nil
+		}
+		cs.mutex.Lock()
+		cs.state.Merge(delta)
+		merged := cs.state.Snapshot()
+		cs.mutex.Unlock()
+
+		cluster.mutex.Lock()
+		for key, value := range merged {
+			cluster.State[key] = value
+		}
+		cluster.mutex.Unlock()
+		return nil
+	})
+
+	wsCtor := js.Global().Get("WebSocket")
+	for _, addr := range peerAddrs {
+		conn := wsCtor.New(addr)
+		conn.Set("onmessage", cs.onMsg)
+		cs.peers = append(cs.peers, conn)
+	}
+
+	syncMutex.Lock()
+	syncClusters[id] = cs
+	syncMutex.Unlock()
+
+	return js.ValueOf("ok")
+}
+
+// broadcastState gossips the cluster's current CRDT delta to every connected peer.
+func broadcastState(this js.Value, args []js.Value) interface{} {
+	if len(args) < 1 {
+		return js.ValueOf("error: cluster ID required")
+	}
+	id := args[0].String()
+
+	syncMutex.RLock()
+	cs, exists := syncClusters[id]
+	syncMutex.RUnlock()
+	if !exists {
+		return js.ValueOf("error: sync not enabled for cluster")
+	}
+
+	cs.mutex.Lock()
+	delta := cs.state.OutgoingDelta()
+	peers := append([]js.Value(nil), cs.peers...)
+	cs.mutex.Unlock()
+
+	deltaJSON, err := json.Marshal(delta)
+	if err != nil {
+		return js.ValueOf("error: failed to encode delta")
+	}
+
+	for _, conn := range peers {
+		conn.Call("send", string(deltaJSON))
+	}
+
+	return js.ValueOf("ok")
+}
+
+// getClusterVersion returns the cluster's version vector as JSON for debugging convergence.
+func getClusterVersion(this js.Value, args []js.Value) interface{} {
+	if len(args) < 1 {
+		return js.Null()
+	}
+	id := args[0].String()
+
+	syncMutex.RLock()
+	cs, exists := syncClusters[id]
+	syncMutex.RUnlock()
+	if !exists {
+		return js.Null()
+	}
+
+	versionJSON, err := json.Marshal(cs.state.VersionVector())
+	if err != nil {
+		return js.Null()
+	}
+	return js.ValueOf(string(versionJSON))
+}
+
+// notifySyncLocal records a local state write in the cluster's CRDT store, if sync is enabled.
+func notifySyncLocal(id string, state map[string]float64) {
+	syncMutex.RLock()
+	cs, exists := syncClusters[id]
+	syncMutex.RUnlock()
+	if !exists {
+		return
+	}
+
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+	for key, value := range state {
+		cs.state.SetLocal(key, value)
+	}
+}
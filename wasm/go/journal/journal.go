@@ -0,0 +1,120 @@
+// Package journal appends decisions and state mutations to a per-cluster
+// durable log, giving deterministic post-mortem debugging and letting a
+// cluster be forked or warm-booted from any historical point. The log
+// itself is plain in-memory bookkeeping; a Persister plugs in whatever
+// durable store backs it (IndexedDB in the browser).
+package journal
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// EntryKind distinguishes the two things a journal records.
+type EntryKind string
+
+const (
+	// KindDecision records a Decision produced by processDecisions.
+	KindDecision EntryKind = "decision"
+	// KindStateChange records an updateClusterState mutation.
+	KindStateChange EntryKind = "state"
+)
+
+// Entry is a single journaled event with its monotonic sequence number.
+type Entry struct {
+	Seq     uint64          `json:"seq"`
+	Kind    EntryKind       `json:"kind"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// Persister durably stores journal entries outside the process, so a log
+// survives a page reload. Persist and Compact are best-effort: the
+// in-memory log is authoritative for the current session.
+type Persister interface {
+	Persist(entry Entry)
+	Compact(uptoSeq uint64)
+}
+
+// Log is an append-only, compactable sequence of journal entries for one cluster.
+type Log struct {
+	mutex     sync.Mutex
+	seq       uint64
+	entries   []Entry
+	persister Persister
+}
+
+// New creates an empty journal. persister may be nil, in which case entries
+// live only in memory for the current session.
+func New(persister Persister) *Log {
+	return &Log{persister: persister}
+}
+
+// Append records a new entry and returns its assigned sequence number.
+func (l *Log) Append(kind EntryKind, payload interface{}) (uint64, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return 0, err
+	}
+
+	l.mutex.Lock()
+	l.seq++
+	entry := Entry{Seq: l.seq, Kind: kind, Payload: data}
+	l.entries = append(l.entries, entry)
+	persister := l.persister
+	l.mutex.Unlock()
+
+	if persister != nil {
+		persister.Persist(entry)
+	}
+	return entry.Seq, nil
+}
+
+// Since returns every entry with Seq > fromSeq, in order.
+func (l *Log) Since(fromSeq uint64) []Entry {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	var out []Entry
+	for _, e := range l.entries {
+		if e.Seq > fromSeq {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// LastSeq returns the sequence number of the most recently appended entry.
+func (l *Log) LastSeq() uint64 {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.seq
+}
+
+// Compact drops every entry with Seq <= uptoSeq, since a snapshot taken at
+// uptoSeq already captures their effect.
+func (l *Log) Compact(uptoSeq uint64) {
+	l.mutex.Lock()
+	kept := l.entries[:0]
+	for _, e := range l.entries {
+		if e.Seq > uptoSeq {
+			kept = append(kept, e)
+		}
+	}
+	l.entries = kept
+	persister := l.persister
+	l.mutex.Unlock()
+
+	if persister != nil {
+		persister.Compact(uptoSeq)
+	}
+}
+
+// Restore fast-forwards the log's sequence counter, e.g. after warm-booting
+// a cluster from a snapshot taken at lastSeq. It does not repopulate
+// entries: the snapshot already captures everything up to lastSeq.
+func (l *Log) Restore(lastSeq uint64) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.seq = lastSeq
+	l.entries = nil
+}
@@ -0,0 +1,107 @@
+package journal
+
+import "testing"
+
+func TestAppendAssignsMonotonicSeq(t *testing.T) {
+	l := New(nil)
+
+	seq1, err := l.Append(KindStateChange, map[string]float64{"energy": 1})
+	if err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	seq2, err := l.Append(KindDecision, map[string]string{"action": "grow"})
+	if err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+
+	if seq1 != 1 || seq2 != 2 {
+		t.Fatalf("expected sequence numbers 1, 2; got %d, %d", seq1, seq2)
+	}
+	if got := l.LastSeq(); got != 2 {
+		t.Fatalf("LastSeq() = %d, want 2", got)
+	}
+}
+
+func TestSinceReturnsOnlyLaterEntries(t *testing.T) {
+	l := New(nil)
+	l.Append(KindStateChange, 1)
+	l.Append(KindStateChange, 2)
+	l.Append(KindStateChange, 3)
+
+	entries := l.Since(1)
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries after seq 1, got %d", len(entries))
+	}
+	if entries[0].Seq != 2 || entries[1].Seq != 3 {
+		t.Fatalf("unexpected entries: %+v", entries)
+	}
+}
+
+func TestCompactDropsEntriesUpToSeq(t *testing.T) {
+	l := New(nil)
+	l.Append(KindStateChange, 1)
+	l.Append(KindStateChange, 2)
+	l.Append(KindStateChange, 3)
+
+	l.Compact(2)
+
+	entries := l.Since(0)
+	if len(entries) != 1 || entries[0].Seq != 3 {
+		t.Fatalf("expected only seq 3 to survive compaction, got %+v", entries)
+	}
+	if got := l.LastSeq(); got != 3 {
+		t.Fatalf("Compact should not rewind LastSeq; got %d", got)
+	}
+}
+
+func TestRestoreFastForwardsSeqAndClearsEntries(t *testing.T) {
+	l := New(nil)
+	l.Append(KindStateChange, 1)
+	l.Append(KindStateChange, 2)
+
+	l.Restore(100)
+
+	if got := l.LastSeq(); got != 100 {
+		t.Fatalf("LastSeq() = %d, want 100", got)
+	}
+	if entries := l.Since(0); len(entries) != 0 {
+		t.Fatalf("expected no entries after Restore, got %+v", entries)
+	}
+
+	seq, err := l.Append(KindDecision, "x")
+	if err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	if seq != 101 {
+		t.Fatalf("expected next seq after restore to be 101, got %d", seq)
+	}
+}
+
+type fakePersister struct {
+	persisted []Entry
+	compacted []uint64
+}
+
+func (f *fakePersister) Persist(entry Entry) {
+	f.persisted = append(f.persisted, entry)
+}
+
+func (f *fakePersister) Compact(uptoSeq uint64) {
+	f.compacted = append(f.compacted, uptoSeq)
+}
+
+func TestAppendAndCompactNotifyPersister(t *testing.T) {
+	fp := &fakePersister{}
+	l := New(fp)
+
+	l.Append(KindStateChange, 1)
+	l.Append(KindStateChange, 2)
+	l.Compact(1)
+
+	if len(fp.persisted) != 2 {
+		t.Fatalf("expected 2 persisted entries, got %d", len(fp.persisted))
+	}
+	if len(fp.compacted) != 1 || fp.compacted[0] != 1 {
+		t.Fatalf("expected one compact call for seq 1, got %v", fp.compacted)
+	}
+}
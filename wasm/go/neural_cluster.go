@@ -7,6 +7,8 @@ import (
 	"syscall/js"
 	"sync"
 	"time"
+
+	"github.com/ouroboros-chimera/neural-cluster/policy"
 )
 
 // NeuralCluster represents a concurrent decision-making process
@@ -14,9 +16,18 @@ type NeuralCluster struct {
 	ID        string
 	State     map[string]float64
 	Active    bool
+	Policy    policy.DecisionPolicy
 	mutex     sync.RWMutex
 	decisions chan Decision
 	stopChan  chan struct{}
+
+	strategy    bufferStrategy
+	subMutex    sync.Mutex
+	subscribers []js.Value
+	pendingMu   sync.Mutex
+	pending     *Decision
+	metricsMu   sync.Mutex
+	metrics     clusterMetrics
 }
 
 // Decision represents a decision made by a neural cluster
@@ -25,6 +36,7 @@ type Decision struct {
 	Action     string  `json:"action"`
 	Confidence float64 `json:"confidence"`
 	Timestamp  int64   `json:"timestamp"`
+	Coalesced  int     `json:"coalesced,omitempty"`
 }
 
 var (
@@ -39,15 +51,19 @@ func createCluster(this js.Value, args []js.Value) interface{} {
 	}
 	
 	id := args[0].String()
-	
+
+	defaultPolicy, _ := policy.New("threshold", nil)
+
 	cluster := &NeuralCluster{
 		ID:        id,
 		State:     make(map[string]float64),
 		Active:    true,
+		Policy:    defaultPolicy,
 		decisions: make(chan Decision, 100),
 		stopChan:  make(chan struct{}),
+		strategy:  strategyDropNewest,
 	}
-	
+
 	// Initialize default state
 	cluster.State["population"] = 50.0
 	cluster.State["energy"] = 50.0
@@ -56,33 +72,52 @@ func createCluster(this js.Value, args []js.Value) interface{} {
 	clusterMutex.Lock()
 	clusters[id] = cluster
 	clusterMutex.Unlock()
-	
+
+	superviseCluster(id)
+
 	// Start decision-making goroutine
 	go cluster.processDecisions()
-	
+
 	return js.ValueOf(id)
 }
 
-// processDecisions runs in a goroutine and continuously makes decisions
+// processDecisions runs in a goroutine and continuously makes decisions.
+// A panic here (e.g. a JS policy callback throwing) is recovered rather
+// than left to crash the whole WASM instance: the goroutine exits as if the
+// cluster had wedged, and the supervisor's heartbeat watchdog restarts it
+// once it notices the missed ticks.
 func (nc *NeuralCluster) processDecisions() {
-	ticker := time.NewTicker(100 * time.Millisecond)
+	defer func() {
+		recover()
+	}()
+
+	sup := superviseCluster(nc.ID)
+	sup.mutex.Lock()
+	tickerMs := sup.config.TickerMs
+	sup.mutex.Unlock()
+
+	ticker := time.NewTicker(time.Duration(tickerMs) * time.Millisecond)
 	defer ticker.Stop()
-	
+
 	for {
 		select {
 		case <-ticker.C:
 			if !nc.Active {
 				return
 			}
+			recordTick(nc.ID)
+			// Pick up a ticker rate changed via goConfigureCluster since the loop started.
+			sup.mutex.Lock()
+			configuredMs := sup.config.TickerMs
+			sup.mutex.Unlock()
+			if configuredMs > 0 && configuredMs != tickerMs {
+				tickerMs = configuredMs
+				ticker.Reset(time.Duration(tickerMs) * time.Millisecond)
+			}
 			// Make decision based on current state
 			decision := nc.makeDecision()
-			
-			// Try to send decision, but don't block if channel is full
-			select {
-			case nc.decisions <- decision:
-			default:
-				// Channel full, skip this decision
-			}
+			journalDecision(nc.ID, decision)
+			nc.deliver(decision)
 			
 		case <-nc.stopChan:
 			return
@@ -90,44 +125,22 @@ func (nc *NeuralCluster) processDecisions() {
 	}
 }
 
-// makeDecision generates a decision based on the cluster's current state
+// makeDecision generates a decision by running the cluster's policy against its current state
 func (nc *NeuralCluster) makeDecision() Decision {
 	nc.mutex.RLock()
-	defer nc.mutex.RUnlock()
-	
-	// Extract state values
-	energy := nc.State["energy"]
-	population := nc.State["population"]
-	mutationRate := nc.State["mutation_rate"]
-	
-	var action string
-	var confidence float64
-	
-	// Decision logic based on state
-	if energy > 70 && population < 80 {
-		action = "grow"
-		confidence = 0.85
-	} else if energy < 30 {
-		action = "conserve"
-		confidence = 0.90
-	} else if population > 120 {
-		action = "reduce"
-		confidence = 0.75
-	} else if mutationRate < 0.03 {
-		action = "mutate_more"
-		confidence = 0.65
-	} else if mutationRate > 0.15 {
-		action = "mutate_less"
-		confidence = 0.70
-	} else {
-		action = "maintain"
-		confidence = 0.60
+	state := make(map[string]float64, len(nc.State))
+	for k, v := range nc.State {
+		state[k] = v
 	}
-	
+	p := nc.Policy
+	nc.mutex.RUnlock()
+
+	outcome := p.Decide(state)
+
 	return Decision{
 		ClusterID:  nc.ID,
-		Action:     action,
-		Confidence: confidence,
+		Action:     outcome.Action,
+		Confidence: outcome.Confidence,
 		Timestamp:  time.Now().Unix(),
 	}
 }
@@ -153,14 +166,26 @@ func updateClusterState(this js.Value, args []js.Value) interface{} {
 	if err := json.Unmarshal([]byte(stateJSON), &state); err != nil {
 		return js.ValueOf("error: invalid JSON")
 	}
-	
+
+	applyStateToCluster(id, cluster, state)
+
+	return js.ValueOf("ok")
+}
+
+// applyStateToCluster merges state into a cluster's State map and fans the
+// mutation out to the sync and journal subsystems. This is the single path
+// both updateClusterState and broadcastStateUpdate use, so every state
+// mutation is replicated and journaled the same way regardless of whether
+// it targeted one cluster or a whole nodelist expression.
+func applyStateToCluster(id string, cluster *NeuralCluster, state map[string]float64) {
 	cluster.mutex.Lock()
 	for key, value := range state {
 		cluster.State[key] = value
 	}
 	cluster.mutex.Unlock()
-	
-	return js.ValueOf("ok")
+
+	notifySyncLocal(id, state)
+	journalStateChange(id, state)
 }
 
 // getClusterDecision retrieves the next decision from a cluster's decision queue
@@ -211,10 +236,11 @@ func stopCluster(this js.Value, args []js.Value) interface{} {
 	// Signal the goroutine to stop
 	cluster.Active = false
 	close(cluster.stopChan)
-	
+
 	// Clean up
 	delete(clusters, id)
-	
+	unsuperviseCluster(id)
+
 	return js.ValueOf("ok")
 }
 
@@ -268,6 +294,23 @@ func main() {
 	js.Global().Set("goStopCluster", js.FuncOf(stopCluster))
 	js.Global().Set("goGetClusterState", js.FuncOf(getClusterState))
 	js.Global().Set("goListClusters", js.FuncOf(listClusters))
-	
+	js.Global().Set("goEnableClusterSync", js.FuncOf(enableClusterSync))
+	js.Global().Set("goBroadcastState", js.FuncOf(broadcastState))
+	js.Global().Set("goGetClusterVersion", js.FuncOf(getClusterVersion))
+	js.Global().Set("goCreateClusterWithPolicy", js.FuncOf(createClusterWithPolicy))
+	js.Global().Set("goSetClusterPolicy", js.FuncOf(setClusterPolicy))
+	js.Global().Set("goRegisterJSPolicy", js.FuncOf(registerJSPolicy))
+	js.Global().Set("goBroadcastStateUpdate", js.FuncOf(broadcastStateUpdate))
+	js.Global().Set("goCollectDecisions", js.FuncOf(collectDecisions))
+	js.Global().Set("goStopClusters", js.FuncOf(stopClusters))
+	js.Global().Set("goSnapshotCluster", js.FuncOf(snapshotCluster))
+	js.Global().Set("goReplayCluster", js.FuncOf(replayCluster))
+	js.Global().Set("goRestoreCluster", js.FuncOf(restoreCluster))
+	js.Global().Set("goSubscribeDecisions", js.FuncOf(subscribeDecisions))
+	js.Global().Set("goGetClusterMetrics", js.FuncOf(getClusterMetrics))
+	js.Global().Set("goConfigureCluster", js.FuncOf(configureCluster))
+	js.Global().Set("goOnClusterStall", js.FuncOf(onClusterStall))
+	js.Global().Set("goGetClusterHealth", js.FuncOf(getClusterHealth))
+
 	<-c
 }
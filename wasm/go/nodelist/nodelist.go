@@ -0,0 +1,270 @@
+// Package nodelist parses compact node-range expressions, in the style of
+// Slurm/pdsh hostlists (e.g. "sensor[01-20]" or "neuron[001-050,100-120]"),
+// into a matcher that can target many clusters with a single expression
+// instead of one round-trip per cluster ID.
+package nodelist
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// atom is one piece of a term: either a fixed string or a bracketed range
+// group. Matching an atom consumes a prefix of the remaining name and
+// returns every remainder left by a valid consumption.
+type atom interface {
+	match(s string) []string
+	expand() []string
+}
+
+// literalAtom matches itself verbatim.
+type literalAtom string
+
+func (a literalAtom) match(s string) []string {
+	if strings.HasPrefix(s, string(a)) {
+		return []string{strings.TrimPrefix(s, string(a))}
+	}
+	return nil
+}
+
+func (a literalAtom) expand() []string {
+	return []string{string(a)}
+}
+
+// rangeSegment is one comma-separated entry inside a bracket group, e.g.
+// "001-050" or a bare "100". width is the zero-padded digit width taken from
+// the segment's own text, so "001-050" only matches 3-digit numbers, while an
+// unpadded "8-12" matches "8" through "12" without leading zeros.
+type rangeSegment struct {
+	lo, hi int
+	width  int
+}
+
+// rangeAtom is a bracket group: "[001-050,100-120]".
+type rangeAtom struct {
+	segments []rangeSegment
+}
+
+func (a rangeAtom) match(s string) []string {
+	var remainders []string
+	for _, seg := range a.segments {
+		maxWidth := len(strconv.Itoa(seg.hi))
+		if maxWidth < seg.width {
+			maxWidth = seg.width
+		}
+		for w := seg.width; w <= maxWidth && w <= len(s); w++ {
+			digits := s[:w]
+			if !isAllDigits(digits) {
+				break
+			}
+			val, err := strconv.Atoi(digits)
+			if err != nil {
+				break
+			}
+			// Accept exactly the spellings expand produces for this segment.
+			if val >= seg.lo && val <= seg.hi && fmt.Sprintf("%0*d", seg.width, val) == digits {
+				remainders = append(remainders, s[w:])
+			}
+		}
+	}
+	return remainders
+}
+
+func (a rangeAtom) expand() []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, seg := range a.segments {
+		for v := seg.lo; v <= seg.hi; v++ {
+			s := fmt.Sprintf("%0*d", seg.width, v)
+			if !seen[s] {
+				seen[s] = true
+				out = append(out, s)
+			}
+		}
+	}
+	return out
+}
+
+func isAllDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return len(s) > 0
+}
+
+// Expr is a parsed nodelist expression: a set of alternative terms, each a
+// sequence of atoms. A name matches if any term fully consumes it.
+type Expr struct {
+	terms [][]atom
+}
+
+// Parse parses a nodelist expression such as "neuron[001-050,100-120]" or
+// "sensor[01-20],cluster5" into a matchable Expr.
+func Parse(expr string) (*Expr, error) {
+	termStrs, err := splitTopLevel(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &Expr{}
+	for _, ts := range termStrs {
+		atoms, err := parseTerm(ts)
+		if err != nil {
+			return nil, err
+		}
+		e.terms = append(e.terms, atoms)
+	}
+	return e, nil
+}
+
+// splitTopLevel splits expr on commas that are not inside a bracket group.
+func splitTopLevel(expr string) ([]string, error) {
+	var terms []string
+	depth := 0
+	start := 0
+	for i, r := range expr {
+		switch r {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("nodelist: unmatched ']' in %q", expr)
+			}
+		case ',':
+			if depth == 0 {
+				terms = append(terms, expr[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("nodelist: unmatched '[' in %q", expr)
+	}
+	terms = append(terms, expr[start:])
+	return terms, nil
+}
+
+// parseTerm parses a single term (no top-level commas) into its atoms:
+// runs of plain text become literalAtoms, each "[...]" becomes a rangeAtom,
+// and a term may contain multiple bracket groups.
+func parseTerm(term string) ([]atom, error) {
+	var atoms []atom
+	i := 0
+	for i < len(term) {
+		open := strings.IndexByte(term[i:], '[')
+		if open == -1 {
+			atoms = append(atoms, literalAtom(term[i:]))
+			break
+		}
+		open += i
+		if open > i {
+			atoms = append(atoms, literalAtom(term[i:open]))
+		}
+
+		close := strings.IndexByte(term[open:], ']')
+		if close == -1 {
+			return nil, fmt.Errorf("nodelist: unmatched '[' in %q", term)
+		}
+		close += open
+
+		ra, err := parseBracket(term[open+1 : close])
+		if err != nil {
+			return nil, err
+		}
+		atoms = append(atoms, ra)
+		i = close + 1
+	}
+	return atoms, nil
+}
+
+// parseBracket parses the comma-separated content of a single bracket group.
+func parseBracket(content string) (rangeAtom, error) {
+	var ra rangeAtom
+	for _, part := range strings.Split(content, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			return ra, fmt.Errorf("nodelist: empty range segment in %q", content)
+		}
+
+		bounds := strings.SplitN(part, "-", 2)
+		startStr := bounds[0]
+		endStr := startStr
+		if len(bounds) == 2 {
+			endStr = bounds[1]
+		}
+
+		lo, err := strconv.Atoi(startStr)
+		if err != nil {
+			return ra, fmt.Errorf("nodelist: invalid range start %q", startStr)
+		}
+		hi, err := strconv.Atoi(endStr)
+		if err != nil {
+			return ra, fmt.Errorf("nodelist: invalid range end %q", endStr)
+		}
+		if hi < lo {
+			return ra, fmt.Errorf("nodelist: range end before start in %q", part)
+		}
+
+		ra.segments = append(ra.segments, rangeSegment{lo: lo, hi: hi, width: len(startStr)})
+	}
+	return ra, nil
+}
+
+// Contains reports whether name is matched by any term of the expression.
+func (e *Expr) Contains(name string) bool {
+	for _, term := range e.terms {
+		if matchTerm(term, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchTerm recursively tries to consume name via atoms, succeeding only
+// when every atom has run and the entire name has been consumed.
+func matchTerm(atoms []atom, name string) bool {
+	if len(atoms) == 0 {
+		return name == ""
+	}
+	for _, rest := range atoms[0].match(name) {
+		if matchTerm(atoms[1:], rest) {
+			return true
+		}
+	}
+	return false
+}
+
+// Expand enumerates every concrete name described by the expression, taking
+// the cartesian product of each term's bracket groups and the union across
+// terms. Duplicate names are collapsed.
+func (e *Expr) Expand() []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, term := range e.terms {
+		for _, name := range expandTerm(term) {
+			if !seen[name] {
+				seen[name] = true
+				out = append(out, name)
+			}
+		}
+	}
+	return out
+}
+
+func expandTerm(atoms []atom) []string {
+	names := []string{""}
+	for _, a := range atoms {
+		var next []string
+		for _, prefix := range names {
+			for _, suffix := range a.expand() {
+				next = append(next, prefix+suffix)
+			}
+		}
+		names = next
+	}
+	return names
+}
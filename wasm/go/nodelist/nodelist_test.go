@@ -0,0 +1,123 @@
+package nodelist
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestParseErrorsOnUnmatchedBrackets(t *testing.T) {
+	for _, expr := range []string{"sensor[01-20", "sensor01-20]", "sensor[01-20],cluster[5"} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q): expected error, got nil", expr)
+		}
+	}
+}
+
+func TestParseErrorsOnEmptyRangeSegment(t *testing.T) {
+	if _, err := Parse("sensor[01-20,]"); err == nil {
+		t.Fatalf("expected error for trailing empty range segment")
+	}
+}
+
+func TestParseErrorsOnInvertedRange(t *testing.T) {
+	if _, err := Parse("sensor[20-01]"); err == nil {
+		t.Fatalf("expected error for range end before start")
+	}
+}
+
+func TestContainsZeroPaddedRange(t *testing.T) {
+	expr, err := Parse("sensor[01-20]")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	for _, name := range []string{"sensor01", "sensor09", "sensor20"} {
+		if !expr.Contains(name) {
+			t.Errorf("expected %q to match sensor[01-20]", name)
+		}
+	}
+
+	for _, name := range []string{"sensor00", "sensor21", "sensor1", "sensor100"} {
+		if expr.Contains(name) {
+			t.Errorf("expected %q not to match sensor[01-20]", name)
+		}
+	}
+}
+
+func TestContainsMultipleBracketGroupsInOneTerm(t *testing.T) {
+	expr, err := Parse("neuron[001-002]-layer[1-3]")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	if !expr.Contains("neuron001-layer1") || !expr.Contains("neuron002-layer3") {
+		t.Fatalf("expected cross product of both bracket groups to match")
+	}
+	if expr.Contains("neuron003-layer1") || expr.Contains("neuron001-layer4") {
+		t.Fatalf("expected out-of-range values on either group to not match")
+	}
+}
+
+func TestContainsCommaSeparatedTerms(t *testing.T) {
+	expr, err := Parse("sensor[01-20],cluster5")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	if !expr.Contains("sensor05") {
+		t.Fatalf("expected sensor05 to match the bracketed term")
+	}
+	if !expr.Contains("cluster5") {
+		t.Fatalf("expected cluster5 to match the literal term")
+	}
+	if expr.Contains("cluster6") {
+		t.Fatalf("expected cluster6 not to match")
+	}
+}
+
+func TestContainsDisjointSegmentsInOneBracket(t *testing.T) {
+	expr, err := Parse("neuron[001-050,100-120]")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	for _, name := range []string{"neuron001", "neuron050", "neuron100", "neuron120"} {
+		if !expr.Contains(name) {
+			t.Errorf("expected %q to match", name)
+		}
+	}
+	if expr.Contains("neuron075") {
+		t.Fatalf("expected neuron075 to fall in the gap between segments and not match")
+	}
+}
+
+func TestExpandProducesExactSetNoDuplicates(t *testing.T) {
+	expr, err := Parse("sensor[01-03],sensor02")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	got := expr.Expand()
+	sort.Strings(got)
+
+	want := []string{"sensor01", "sensor02", "sensor03"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Expand() = %v, want %v", got, want)
+	}
+}
+
+func TestExpandCartesianProductAcrossBracketGroups(t *testing.T) {
+	expr, err := Parse("neuron[1-2]layer[1-2]")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	got := expr.Expand()
+	sort.Strings(got)
+
+	want := []string{"neuron1layer1", "neuron1layer2", "neuron2layer1", "neuron2layer2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Expand() = %v, want %v", got, want)
+	}
+}
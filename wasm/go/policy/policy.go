@@ -0,0 +1,221 @@
+// Package policy defines the decision-making strategy used by a neural
+// cluster on each tick. Strategies are pluggable: callers register a named
+// factory and clusters are created against a policy name plus parameters,
+// rather than the decision logic being hard-coded into the cluster itself.
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Outcome is the result of a policy decision, independent of any particular
+// cluster so that this package has no dependency on the cluster type.
+type Outcome struct {
+	Action     string
+	Confidence float64
+}
+
+// DecisionPolicy decides an action from a cluster's current state.
+type DecisionPolicy interface {
+	Decide(state map[string]float64) Outcome
+}
+
+// Factory builds a DecisionPolicy from its JSON-encoded parameters.
+type Factory func(params json.RawMessage) (DecisionPolicy, error)
+
+var (
+	registryMutex sync.RWMutex
+	registry      = make(map[string]Factory)
+)
+
+// RegisterPolicy makes a named factory available to New. Registering under
+// an existing name replaces it, which lets callers override a built-in.
+func RegisterPolicy(name string, f Factory) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	registry[name] = f
+}
+
+// New builds the named policy from params. A nil or empty params selects the
+// policy's defaults; a policy without defaults, such as "feedforward", which
+// needs its weights and actions in params, returns an error instead.
+func New(name string, params json.RawMessage) (DecisionPolicy, error) {
+	registryMutex.RLock()
+	f, ok := registry[name]
+	registryMutex.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("policy: unknown policy %q", name)
+	}
+	return f(params)
+}
+
+func init() {
+	RegisterPolicy("threshold", func(json.RawMessage) (DecisionPolicy, error) {
+		return ThresholdPolicy{}, nil
+	})
+	RegisterPolicy("softmax", newSoftmaxPolicy)
+	RegisterPolicy("feedforward", newFeedForwardPolicy)
+}
+
+// ThresholdPolicy is the original hard-coded rule set: the first matching
+// energy/population/mutation-rate band wins.
+type ThresholdPolicy struct{}
+
+// Decide implements DecisionPolicy.
+func (ThresholdPolicy) Decide(state map[string]float64) Outcome {
+	energy := state["energy"]
+	population := state["population"]
+	mutationRate := state["mutation_rate"]
+
+	switch {
+	case energy > 70 && population < 80:
+		return Outcome{Action: "grow", Confidence: 0.85}
+	case energy < 30:
+		return Outcome{Action: "conserve", Confidence: 0.90}
+	case population > 120:
+		return Outcome{Action: "reduce", Confidence: 0.75}
+	case mutationRate < 0.03:
+		return Outcome{Action: "mutate_more", Confidence: 0.65}
+	case mutationRate > 0.15:
+		return Outcome{Action: "mutate_less", Confidence: 0.70}
+	default:
+		return Outcome{Action: "maintain", Confidence: 0.60}
+	}
+}
+
+// softmaxActions and their scoring functions mirror ThresholdPolicy's bands,
+// but as continuous scores rather than hard cutoffs.
+var softmaxActions = []struct {
+	name  string
+	score func(state map[string]float64) float64
+}{
+	{"grow", func(s map[string]float64) float64 { return (s["energy"] - 70) + (80 - s["population"]) }},
+	{"conserve", func(s map[string]float64) float64 { return 30 - s["energy"] }},
+	{"reduce", func(s map[string]float64) float64 { return s["population"] - 120 }},
+	{"mutate_more", func(s map[string]float64) float64 { return (0.03 - s["mutation_rate"]) * 100 }},
+	{"mutate_less", func(s map[string]float64) float64 { return (s["mutation_rate"] - 0.15) * 100 }},
+	{"maintain", func(s map[string]float64) float64 { return 0 }},
+}
+
+// SoftmaxPolicy samples an action weighted by exp(score/temperature) instead
+// of always taking the highest-scoring one, trading determinism for
+// exploration.
+type SoftmaxPolicy struct {
+	Temperature float64
+	rng         *rand.Rand
+}
+
+type softmaxParams struct {
+	Temperature float64 `json:"temperature"`
+}
+
+func newSoftmaxPolicy(params json.RawMessage) (DecisionPolicy, error) {
+	p := softmaxParams{Temperature: 1.0}
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, fmt.Errorf("policy: invalid softmax params: %w", err)
+		}
+	}
+	if p.Temperature <= 0 {
+		return nil, fmt.Errorf("policy: softmax temperature must be positive")
+	}
+	return &SoftmaxPolicy{Temperature: p.Temperature, rng: rand.New(rand.NewSource(nextSeed()))}, nil
+}
+
+// seedCounter distinguishes policies created within the same nanosecond, so
+// two clusters spun up back-to-back don't share an RNG seed.
+var seedCounter uint64
+
+// nextSeed derives a per-instance RNG seed instead of a hardcoded constant,
+// so each softmax policy actually samples independently rather than
+// replaying the same "random" sequence every run.
+func nextSeed() int64 {
+	return time.Now().UnixNano() + int64(atomic.AddUint64(&seedCounter, 1))
+}
+
+// Decide implements DecisionPolicy.
+func (p *SoftmaxPolicy) Decide(state map[string]float64) Outcome {
+	weights := make([]float64, len(softmaxActions))
+	var total float64
+	for i, a := range softmaxActions {
+		weights[i] = math.Exp(a.score(state) / p.Temperature)
+		total += weights[i]
+	}
+
+	pick := p.rng.Float64() * total
+	var cumulative float64
+	for i, w := range weights {
+		cumulative += w
+		if pick <= cumulative {
+			return Outcome{Action: softmaxActions[i].name, Confidence: w / total}
+		}
+	}
+
+	last := len(softmaxActions) - 1
+	return Outcome{Action: softmaxActions[last].name, Confidence: weights[last] / total}
+}
+
+// feedForwardInputs is the fixed input ordering fed to a FeedForwardPolicy.
+var feedForwardInputs = []string{"energy", "population", "mutation_rate"}
+
+// FeedForwardPolicy is a single-layer network: each output unit is a tanh of
+// a weighted sum of the cluster state, and the action is the argmax unit.
+type FeedForwardPolicy struct {
+	Weights [][]float64 // one row per output unit, one column per input plus a bias term
+	Actions []string
+}
+
+type feedForwardParams struct {
+	Weights [][]float64 `json:"weights"`
+	Actions []string    `json:"actions"`
+}
+
+func newFeedForwardPolicy(params json.RawMessage) (DecisionPolicy, error) {
+	var p feedForwardParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, fmt.Errorf("policy: invalid feedforward params: %w", err)
+	}
+	if len(p.Weights) == 0 {
+		return nil, fmt.Errorf("policy: feedforward needs at least one output unit")
+	}
+	if len(p.Weights) != len(p.Actions) {
+		return nil, fmt.Errorf("policy: feedforward needs one weight row per action")
+	}
+	for _, row := range p.Weights {
+		if len(row) != len(feedForwardInputs)+1 {
+			return nil, fmt.Errorf("policy: feedforward weight row must have %d entries (inputs + bias)", len(feedForwardInputs)+1)
+		}
+	}
+	return &FeedForwardPolicy{Weights: p.Weights, Actions: p.Actions}, nil
+}
+
+// Decide implements DecisionPolicy.
+func (p *FeedForwardPolicy) Decide(state map[string]float64) Outcome {
+	inputs := make([]float64, len(feedForwardInputs))
+	for i, key := range feedForwardInputs {
+		inputs[i] = state[key]
+	}
+
+	bestIdx := 0
+	bestVal := math.Inf(-1)
+	outputs := make([]float64, len(p.Weights))
+	for i, row := range p.Weights {
+		sum := row[len(inputs)] // bias
+		for j, in := range inputs {
+			sum += row[j] * in
+		}
+		outputs[i] = math.Tanh(sum)
+		if outputs[i] > bestVal {
+			bestVal = outputs[i]
+			bestIdx = i
+		}
+	}
+
+	return Outcome{Action: p.Actions[bestIdx], Confidence: (bestVal + 1) / 2}
+}
@@ -0,0 +1,115 @@
+package policy
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestThresholdPolicyGrow(t *testing.T) {
+	p := ThresholdPolicy{}
+	outcome := p.Decide(map[string]float64{"energy": 80, "population": 50, "mutation_rate": 0.05})
+	if outcome.Action != "grow" {
+		t.Fatalf("expected grow, got %s", outcome.Action)
+	}
+}
+
+func TestNewSoftmaxPolicyRejectsNonPositiveTemperature(t *testing.T) {
+	params, _ := json.Marshal(softmaxParams{Temperature: 0})
+	if _, err := New("softmax", params); err == nil {
+		t.Fatalf("expected error for zero temperature")
+	}
+}
+
+func TestSoftmaxPolicyAlwaysReturnsAKnownAction(t *testing.T) {
+	pol, err := New("softmax", nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	known := make(map[string]bool, len(softmaxActions))
+	for _, a := range softmaxActions {
+		known[a.name] = true
+	}
+
+	state := map[string]float64{"energy": 80, "population": 50, "mutation_rate": 0.05}
+	for i := 0; i < 50; i++ {
+		outcome := pol.Decide(state)
+		if !known[outcome.Action] {
+			t.Fatalf("Decide returned unknown action %q", outcome.Action)
+		}
+	}
+}
+
+func TestTwoSoftmaxPoliciesDoNotShareASeed(t *testing.T) {
+	// A high temperature spreads the weights over all six actions, each with
+	// a distinct probability; at the default temperature this state gives
+	// "grow" nearly all the weight and any two policies would agree.
+	params, _ := json.Marshal(softmaxParams{Temperature: 100})
+	a, err := New("softmax", params)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	b, err := New("softmax", params)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	state := map[string]float64{"energy": 50, "population": 50, "mutation_rate": 0.05}
+	same := true
+	for i := 0; i < 20; i++ {
+		if a.Decide(state).Confidence != b.Decide(state).Confidence {
+			same = false
+			break
+		}
+	}
+	if same {
+		t.Fatalf("two independently-created softmax policies produced identical sequences, suggesting a shared/fixed seed")
+	}
+}
+
+func TestNewFeedForwardPolicyRejectsZeroLengthWeights(t *testing.T) {
+	params, _ := json.Marshal(feedForwardParams{Weights: nil, Actions: nil})
+	if _, err := New("feedforward", params); err == nil {
+		t.Fatalf("expected error for zero-length weights/actions")
+	}
+}
+
+func TestNewFeedForwardPolicyRejectsMismatchedWeightsAndActions(t *testing.T) {
+	params, _ := json.Marshal(feedForwardParams{
+		Weights: [][]float64{{1, 1, 1, 1}},
+		Actions: []string{"grow", "conserve"},
+	})
+	if _, err := New("feedforward", params); err == nil {
+		t.Fatalf("expected error for mismatched weight rows and actions")
+	}
+}
+
+func TestNewFeedForwardPolicyRejectsWrongRowWidth(t *testing.T) {
+	params, _ := json.Marshal(feedForwardParams{
+		Weights: [][]float64{{1, 1}},
+		Actions: []string{"grow"},
+	})
+	if _, err := New("feedforward", params); err == nil {
+		t.Fatalf("expected error for a weight row missing inputs/bias entries")
+	}
+}
+
+func TestFeedForwardPolicyPicksArgmaxUnit(t *testing.T) {
+	// "grow" fires hard positive on energy, "conserve" is held at a constant negative bias.
+	params, _ := json.Marshal(feedForwardParams{
+		Weights: [][]float64{
+			{1, 0, 0, 0},  // grow:     tanh(energy)
+			{0, 0, 0, -1}, // conserve: tanh(-1), always negative
+		},
+		Actions: []string{"grow", "conserve"},
+	})
+	pol, err := New("feedforward", params)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	outcome := pol.Decide(map[string]float64{"energy": 10, "population": 0, "mutation_rate": 0})
+	if outcome.Action != "grow" {
+		t.Fatalf("expected grow to win the argmax, got %s", outcome.Action)
+	}
+}
@@ -0,0 +1,148 @@
+// Package sync implements a CRDT-based replication layer for cluster state.
+// State is exposed as a merge-safe LWW-register store rather than a raft
+// log, so peers can gossip deltas and converge without a central
+// coordinator, tolerating out-of-order and duplicate delivery.
+package sync
+
+import "sync"
+
+// LWWRegister is a last-writer-wins register keyed by (Lamport clock, peerID).
+// Ties are broken by peerID so merges stay deterministic across replicas.
+type LWWRegister struct {
+	Value  float64 `json:"value"`
+	Clock  uint64  `json:"clock"`
+	PeerID string  `json:"peerId"`
+}
+
+// Merge folds other into r, keeping whichever write is newer. It is
+// commutative, associative and idempotent, so repeated or reordered
+// deliveries converge to the same result.
+func (r LWWRegister) Merge(other LWWRegister) LWWRegister {
+	if other.Clock > r.Clock {
+		return other
+	}
+	if other.Clock == r.Clock && other.PeerID > r.PeerID {
+		return other
+	}
+	return r
+}
+
+// Delta is a gossip message: the set of register updates a peer wants to
+// propagate to the rest of the cluster.
+type Delta struct {
+	PeerID    string                 `json:"peerId"`
+	Registers map[string]LWWRegister `json:"registers"`
+	Version   map[string]uint64      `json:"version"`
+}
+
+// State is a CRDT-replicated wrapper around cluster state: every field is
+// an LWW register. cluster.State fields are always written as absolute
+// values (see updateClusterState), and LWW is the CRDT that converges
+// absolute-set writes correctly, unlike a PN-counter which would sum
+// concurrent writers' values instead of picking one.
+type State struct {
+	mutex     sync.RWMutex
+	peerID    string
+	clock     uint64
+	registers map[string]LWWRegister
+	version   map[string]uint64
+}
+
+// NewState creates an empty replicated state for the local peer.
+func NewState(peerID string) *State {
+	return &State{
+		peerID:    peerID,
+		registers: make(map[string]LWWRegister),
+		version:   make(map[string]uint64),
+	}
+}
+
+// SetLocal applies a local write, bumping the Lamport clock and this peer's
+// entry in the version vector.
+func (s *State) SetLocal(key string, value float64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.clock++
+	s.version[s.peerID] = s.clock
+	s.registers[key] = LWWRegister{Value: value, Clock: s.clock, PeerID: s.peerID}
+}
+
+// Snapshot returns the merged view of all replicated fields.
+func (s *State) Snapshot() map[string]float64 {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	out := make(map[string]float64, len(s.registers))
+	for key, reg := range s.registers {
+		out[key] = reg.Value
+	}
+	return out
+}
+
+// OutgoingDelta packages the full local state as a Delta suitable for gossip.
+// Sending the whole state (rather than just the last write) keeps convergence
+// correct even when a peer misses several rounds.
+func (s *State) OutgoingDelta() Delta {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	registers := make(map[string]LWWRegister, len(s.registers))
+	for k, v := range s.registers {
+		registers[k] = v
+	}
+
+	version := make(map[string]uint64, len(s.version))
+	for k, v := range s.version {
+		version[k] = v
+	}
+
+	return Delta{
+		PeerID:    s.peerID,
+		Registers: registers,
+		Version:   version,
+	}
+}
+
+// Merge applies a remote Delta. Registers merge via LWW and the version
+// vector takes the elementwise max, so merge is safe to call repeatedly or
+// out of order. s.clock also advances to the highest clock seen in the
+// delta: without this, a freshly-joined peer's local clock never catches up
+// to an established peer's, so its writes would lose LWW's clock comparison
+// forever regardless of how recent they actually are.
+func (s *State) Merge(delta Delta) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for key, remote := range delta.Registers {
+		if local, ok := s.registers[key]; ok {
+			s.registers[key] = local.Merge(remote)
+		} else {
+			s.registers[key] = remote
+		}
+		if remote.Clock > s.clock {
+			s.clock = remote.Clock
+		}
+	}
+
+	for peer, clock := range delta.Version {
+		if clock > s.version[peer] {
+			s.version[peer] = clock
+		}
+		if clock > s.clock {
+			s.clock = clock
+		}
+	}
+}
+
+// VersionVector returns a copy of the state's version vector for debugging.
+func (s *State) VersionVector() map[string]uint64 {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	out := make(map[string]uint64, len(s.version))
+	for k, v := range s.version {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,74 @@
+package sync
+
+import "testing"
+
+func TestStateConvergesRegardlessOfMergeOrder(t *testing.T) {
+	a := NewState("peerA")
+	b := NewState("peerB")
+
+	a.SetLocal("population", 50)
+	b.SetLocal("population", 80)
+
+	deltaA := a.OutgoingDelta()
+	deltaB := b.OutgoingDelta()
+
+	a.Merge(deltaB)
+	b.Merge(deltaA)
+
+	snapA := a.Snapshot()
+	snapB := b.Snapshot()
+	if snapA["population"] != snapB["population"] {
+		t.Fatalf("peers diverged after merging each other's delta: a=%v b=%v", snapA, snapB)
+	}
+}
+
+func TestMergeIsIdempotent(t *testing.T) {
+	a := NewState("peerA")
+	b := NewState("peerB")
+
+	a.SetLocal("energy", 42)
+	delta := a.OutgoingDelta()
+
+	b.Merge(delta)
+	first := b.Snapshot()["energy"]
+
+	b.Merge(delta)
+	b.Merge(delta)
+	second := b.Snapshot()["energy"]
+
+	if first != second {
+		t.Fatalf("merge is not idempotent: first=%v second=%v", first, second)
+	}
+}
+
+func TestNewPeerClockCatchesUpOnMerge(t *testing.T) {
+	established := NewState("established")
+	for i := 0; i < 10; i++ {
+		established.SetLocal("energy", float64(i))
+	}
+
+	newcomer := NewState("newcomer")
+	newcomer.Merge(established.OutgoingDelta())
+
+	// The newcomer's own next write should win against the established
+	// peer's last write, since it happens after the merge. If the
+	// newcomer's clock never advanced past its own local counter, this
+	// write would still lose LWW's clock comparison.
+	newcomer.SetLocal("energy", 999)
+
+	established.Merge(newcomer.OutgoingDelta())
+
+	if got := established.Snapshot()["energy"]; got != 999 {
+		t.Fatalf("newcomer's later write did not win LWW after clock catch-up: got %v", got)
+	}
+}
+
+func TestSetLocalIsAbsoluteNotAdditive(t *testing.T) {
+	s := NewState("peer")
+	s.SetLocal("population", 50)
+	s.SetLocal("population", 60)
+
+	if got := s.Snapshot()["population"]; got != 60 {
+		t.Fatalf("expected last local write to win, got %v", got)
+	}
+}